@@ -0,0 +1,138 @@
+package asm
+
+import "testing"
+
+// rawOpcodes covers one instruction from every opcode family Disassemble
+// and Assemble both understand.
+var rawOpcodes = []uint16{
+	0x00E0, // CLS
+	0x00EE, // RET
+	0x1234, // JP 0x234
+	0x2234, // CALL 0x234
+	0x3A12, // SE VA, 0x12
+	0x4A12, // SNE VA, 0x12
+	0x5AB0, // SE VA, VB
+	0x6A12, // LD VA, 0x12
+	0x7A12, // ADD VA, 0x12
+	0x8AB0, // LD VA, VB
+	0x8AB1, // OR VA, VB
+	0x8AB2, // AND VA, VB
+	0x8AB3, // XOR VA, VB
+	0x8AB4, // ADD VA, VB
+	0x8AB5, // SUB VA, VB
+	0x8AB6, // SHR VA {, VB}
+	0x8AB7, // SUBN VA, VB
+	0x8ABE, // SHL VA {, VB}
+	0x9AB0, // SNE VA, VB
+	0xA234, // LD I, 0x234
+	0xB234, // JP V0, 0x234
+	0xCA12, // RND VA, 0x12
+	0xDAB5, // DRW VA, VB, 5
+	0xEA9E, // SKP VA
+	0xEAA1, // SKNP VA
+	0xFA07, // LD VA, DT
+	0xFA0A, // LD VA, K
+	0xFA15, // LD DT, VA
+	0xFA18, // LD ST, VA
+	0xFA1E, // ADD I, VA
+	0xFA29, // LD F, VA
+	0xFA33, // LD B, VA
+	0xFA55, // LD [I], VA
+	0xFA65, // LD VA, [I]
+}
+
+func rawToBytes(raw uint16) []byte {
+	return []byte{byte(raw >> 8), byte(raw)}
+}
+
+func TestDisassembleThenAssembleRoundTrips(t *testing.T) {
+	for _, raw := range rawOpcodes {
+		code := rawToBytes(raw)
+		instrs := Disassemble(code, loadAddr)
+		if len(instrs) != 1 {
+			t.Fatalf("raw 0x%04X: Disassemble returned %d instructions, want 1", raw, len(instrs))
+		}
+		ins := instrs[0]
+		if ins.Op == DATA {
+			t.Fatalf("raw 0x%04X: decoded as DATA, want a known mnemonic", raw)
+		}
+
+		got, err := Assemble(ins.String())
+		if err != nil {
+			t.Fatalf("raw 0x%04X: Assemble(%q): %v", raw, ins.String(), err)
+		}
+		if len(got) != 2 || got[0] != code[0] || got[1] != code[1] {
+			t.Fatalf("raw 0x%04X: round-tripped through %q as 0x%02X%02X, want 0x%04X", raw, ins.String(), got[0], got[1], raw)
+		}
+	}
+}
+
+func TestDisassembleUnknownOpcodeIsData(t *testing.T) {
+	instrs := Disassemble([]byte{0x00, 0x01}, loadAddr) // 0x0001: not CLS/RET
+	if len(instrs) != 1 || instrs[0].Op != DATA {
+		t.Fatalf("got %+v, want a single DATA instruction", instrs)
+	}
+}
+
+func TestAssembleResolvesForwardAndBackwardLabels(t *testing.T) {
+	src := `
+start:
+	JP next
+loop:
+	ADD V0, 0x01
+	JP loop
+next:
+	CALL loop
+`
+	code, err := Assemble(src)
+	if err != nil {
+		t.Fatalf("Assemble: %v", err)
+	}
+
+	instrs := Disassemble(code, loadAddr)
+	if len(instrs) != 4 {
+		t.Fatalf("got %d instructions, want 4", len(instrs))
+	}
+
+	// start: JP next -> next is defined after 3 instructions (loadAddr+6)
+	if instrs[0].Op != JP || instrs[0].Args[0].Value != loadAddr+6 {
+		t.Fatalf("instrs[0] = %+v, want JP to 0x%03X", instrs[0], loadAddr+6)
+	}
+	if instrs[1].Op != ADD {
+		t.Fatalf("instrs[1] = %+v, want ADD", instrs[1])
+	}
+	// loop: JP loop -> loop is loadAddr+2, right after the first JP
+	if instrs[2].Op != JP || instrs[2].Args[0].Value != loadAddr+2 {
+		t.Fatalf("instrs[2] = %+v, want JP to 0x%03X", instrs[2], loadAddr+2)
+	}
+	// next: CALL loop -> same target as the JP above
+	if instrs[3].Op != CALL || instrs[3].Args[0].Value != loadAddr+2 {
+		t.Fatalf("instrs[3] = %+v, want CALL to 0x%03X", instrs[3], loadAddr+2)
+	}
+}
+
+func TestAssembleRejectsUndefinedLabel(t *testing.T) {
+	if _, err := Assemble("JP nowhere"); err == nil {
+		t.Fatal("Assemble accepted a reference to an undefined label")
+	}
+}
+
+func TestAssembleRejectsRedefinedLabel(t *testing.T) {
+	src := `
+again:
+	ADD V0, 0x01
+again:
+	ADD V0, 0x02
+`
+	if _, err := Assemble(src); err == nil {
+		t.Fatal("Assemble accepted a redefined label")
+	}
+}
+
+func TestAssembleRejectsMissingSecondOperand(t *testing.T) {
+	for _, src := range []string{"SE V0", "SNE V0", "ADD V0"} {
+		if _, err := Assemble(src); err == nil {
+			t.Fatalf("Assemble(%q) returned no error, want one operand-count error instead of a panic", src)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// loadAddr is the conventional CHIP-8 ROM load address; Assemble resolves
+// labels as if the emitted bytes were loaded there, matching where
+// Chip8.pc starts execution.
+const loadAddr = 0x200
+
+// Assemble parses the textual syntax produced by Instruction.String (one
+// instruction or "label:" per line, ";" starts a line comment) into raw
+// CHIP-8 bytes, resolving labels used by JP, CALL and LD I, addr. DATA,
+// which String renders without its raw word, is not accepted.
+func Assemble(src string) ([]byte, error) {
+	lines := strings.Split(src, "\n")
+
+	symbols := map[string]uint16{}
+	addr := uint16(loadAddr)
+	for lineNo, raw := range lines {
+		line := stripComment(raw)
+		if line == "" {
+			continue
+		}
+		if label, ok := strings.CutSuffix(line, ":"); ok {
+			label = strings.TrimSpace(label)
+			if label == "" {
+				return nil, fmt.Errorf("line %d: empty label", lineNo+1)
+			}
+			if _, exists := symbols[label]; exists {
+				return nil, fmt.Errorf("line %d: label %q redefined", lineNo+1, label)
+			}
+			symbols[label] = addr
+			continue
+		}
+		addr += 2
+	}
+
+	out := make([]byte, 0, addr-loadAddr)
+	addr = loadAddr
+	for lineNo, raw := range lines {
+		line := stripComment(raw)
+		if line == "" {
+			continue
+		}
+		if _, ok := strings.CutSuffix(line, ":"); ok {
+			continue
+		}
+		word, err := assembleLine(line, symbols)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+		}
+		out = append(out, byte(word>>8), byte(word))
+		addr += 2
+	}
+
+	return out, nil
+}
+
+func stripComment(line string) string {
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line)
+}
+
+func assembleLine(line string, symbols map[string]uint16) (uint16, error) {
+	mnemonic, rest, _ := strings.Cut(line, " ")
+	mnemonic = strings.ToUpper(strings.TrimSpace(mnemonic))
+
+	var operands []string
+	if rest = strings.TrimSpace(rest); rest != "" {
+		for _, op := range strings.Split(rest, ",") {
+			operands = append(operands, strings.TrimSpace(op))
+		}
+	}
+
+	switch OpCode(mnemonic) {
+	case CLS:
+		return 0x00E0, nil
+	case RET:
+		return 0x00EE, nil
+	case JP:
+		if len(operands) == 2 {
+			if strings.ToUpper(operands[0]) != "V0" {
+				return 0, fmt.Errorf("JP with two operands must be JP V0, addr, got %q", operands[0])
+			}
+			addr, err := resolveAddr(operands[1], symbols)
+			if err != nil {
+				return 0, err
+			}
+			return 0xB000 | addr, nil
+		}
+		addr, err := operand(operands, 0, func(s string) (uint16, error) { return resolveAddr(s, symbols) })
+		if err != nil {
+			return 0, err
+		}
+		return 0x1000 | addr, nil
+	case CALL:
+		addr, err := operand(operands, 0, func(s string) (uint16, error) { return resolveAddr(s, symbols) })
+		if err != nil {
+			return 0, err
+		}
+		return 0x2000 | addr, nil
+	case SE, SNE:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		if len(operands) != 2 {
+			return 0, fmt.Errorf("%s expects 2 operands, got %d", mnemonic, len(operands))
+		}
+		base := uint16(0x5000)
+		if mnemonic == "SNE" {
+			base = 0x9000
+		}
+		if y, err := reg(operands[1]); err == nil {
+			return base | x<<8 | y<<4, nil
+		}
+		nn, err := immediateByte(operands[1])
+		if err != nil {
+			return 0, err
+		}
+		if mnemonic == "SE" {
+			return 0x3000 | x<<8 | nn, nil
+		}
+		return 0x4000 | x<<8 | nn, nil
+	case LD:
+		return assembleLD(operands, symbols)
+	case ADD:
+		if len(operands) > 0 && operands[0] == "I" {
+			vy, err := operand(operands, 1, reg)
+			if err != nil {
+				return 0, err
+			}
+			return 0xF01E | vy<<8, nil
+		}
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		if len(operands) != 2 {
+			return 0, fmt.Errorf("ADD expects 2 operands, got %d", len(operands))
+		}
+		if y, err := reg(operands[1]); err == nil {
+			return 0x8004 | x<<8 | y<<4, nil
+		}
+		nn, err := immediateByte(operands[1])
+		if err != nil {
+			return 0, err
+		}
+		return 0x7000 | x<<8 | nn, nil
+	case OR, AND, XOR, SUB, SUBN:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		y, err := operand(operands, 1, reg)
+		if err != nil {
+			return 0, err
+		}
+		return 0x8000 | x<<8 | y<<4 | aluNibble(OpCode(mnemonic)), nil
+	case SHR, SHL:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		y := x
+		if len(operands) > 1 {
+			if y, err = reg(operands[1]); err != nil {
+				return 0, err
+			}
+		}
+		nibble := uint16(0x6)
+		if mnemonic == "SHL" {
+			nibble = 0xE
+		}
+		return 0x8000 | x<<8 | y<<4 | nibble, nil
+	case RND:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		nn, err := operand(operands, 1, immediateByte)
+		if err != nil {
+			return 0, err
+		}
+		return 0xC000 | x<<8 | nn, nil
+	case DRW:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		y, err := operand(operands, 1, reg)
+		if err != nil {
+			return 0, err
+		}
+		n, err := operand(operands, 2, immediateByte)
+		if err != nil {
+			return 0, err
+		}
+		return 0xD000 | x<<8 | y<<4 | n&0xF, nil
+	case SKP:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		return 0xE09E | x<<8, nil
+	case SKNP:
+		x, err := operand(operands, 0, reg)
+		if err != nil {
+			return 0, err
+		}
+		return 0xE0A1 | x<<8, nil
+	default:
+		return 0, fmt.Errorf("unknown mnemonic %q", mnemonic)
+	}
+}
+
+func assembleLD(operands []string, symbols map[string]uint16) (uint16, error) {
+	if len(operands) != 2 {
+		return 0, fmt.Errorf("LD expects 2 operands, got %d", len(operands))
+	}
+	dst, src := operands[0], operands[1]
+
+	switch {
+	case dst == "I":
+		addr, err := resolveAddr(src, symbols)
+		if err != nil {
+			return 0, err
+		}
+		return 0xA000 | addr, nil
+	case dst == "[I]":
+		x, err := reg(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0xF055 | x<<8, nil
+	case dst == "DT":
+		x, err := reg(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0xF015 | x<<8, nil
+	case dst == "ST":
+		x, err := reg(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0xF018 | x<<8, nil
+	case dst == "F":
+		x, err := reg(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0xF029 | x<<8, nil
+	case dst == "B":
+		x, err := reg(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0xF033 | x<<8, nil
+	}
+
+	x, err := reg(dst)
+	if err != nil {
+		return 0, err
+	}
+	switch src {
+	case "DT":
+		return 0xF007 | x<<8, nil
+	case "K":
+		return 0xF00A | x<<8, nil
+	case "[I]":
+		return 0xF065 | x<<8, nil
+	}
+	if y, err := reg(src); err == nil {
+		return 0x8000 | x<<8 | y<<4, nil
+	}
+	nn, err := immediateByte(src)
+	if err != nil {
+		return 0, err
+	}
+	return 0x6000 | x<<8 | nn, nil
+}
+
+func aluNibble(op OpCode) uint16 {
+	switch op {
+	case OR:
+		return 0x1
+	case AND:
+		return 0x2
+	case XOR:
+		return 0x3
+	case SUB:
+		return 0x5
+	case SUBN:
+		return 0x7
+	default:
+		return 0x0
+	}
+}
+
+func operand(operands []string, i int, parse func(string) (uint16, error)) (uint16, error) {
+	if i >= len(operands) {
+		return 0, fmt.Errorf("missing operand %d", i+1)
+	}
+	return parse(operands[i])
+}
+
+func reg(tok string) (uint16, error) {
+	tok = strings.ToUpper(tok)
+	if len(tok) < 2 || tok[0] != 'V' {
+		return 0, fmt.Errorf("not a register: %q", tok)
+	}
+	v, err := strconv.ParseUint(tok[1:], 16, 8)
+	if err != nil || v > 0xF {
+		return 0, fmt.Errorf("invalid register: %q", tok)
+	}
+	return uint16(v), nil
+}
+
+func immediateByte(tok string) (uint16, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(tok), "0x"), hexOrDec(tok), 16)
+	if err != nil || v > 0xFF {
+		return 0, fmt.Errorf("invalid byte literal: %q", tok)
+	}
+	return uint16(v), nil
+}
+
+func resolveAddr(tok string, symbols map[string]uint16) (uint16, error) {
+	if addr, ok := symbols[tok]; ok {
+		return addr & 0x0FFF, nil
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(tok), "0x"), hexOrDec(tok), 16)
+	if err != nil || v > 0x0FFF {
+		return 0, fmt.Errorf("undefined label or invalid address: %q", tok)
+	}
+	return uint16(v), nil
+}
+
+func hexOrDec(tok string) int {
+	if strings.HasPrefix(strings.ToLower(tok), "0x") {
+		return 16
+	}
+	return 10
+}
@@ -0,0 +1,137 @@
+package asm
+
+// Disassemble decodes every 16-bit word in rom into an Instruction, assuming
+// rom was loaded into memory starting at loadAddr. It does not attempt to
+// distinguish code from data: callers that know a ROM embeds sprite data
+// should skip those addresses themselves.
+func Disassemble(rom []byte, loadAddr uint16) []Instruction {
+	instructions := make([]Instruction, 0, len(rom)/2)
+	for i := 0; i+1 < len(rom); i += 2 {
+		raw := uint16(rom[i])<<8 | uint16(rom[i+1])
+		instructions = append(instructions, decode(loadAddr+uint16(i), raw))
+	}
+	return instructions
+}
+
+func decode(addr uint16, raw uint16) Instruction {
+	x := (raw & 0x0F00) >> 8
+	y := (raw & 0x00F0) >> 4
+	n := raw & 0x000F
+	nn := raw & 0x00FF
+	nnn := raw & 0x0FFF
+
+	ins := Instruction{Addr: addr, Raw: raw}
+
+	switch raw & 0xF000 {
+	case 0x0000:
+		switch raw {
+		case 0x00E0:
+			ins.Op = CLS
+		case 0x00EE:
+			ins.Op = RET
+		default:
+			ins.Op = DATA
+		}
+	case 0x1000:
+		ins.Op = JP
+		ins.Args = []Arg{{Kind: KindAddr, Value: nnn}}
+	case 0x2000:
+		ins.Op = CALL
+		ins.Args = []Arg{{Kind: KindAddr, Value: nnn}}
+	case 0x3000:
+		ins.Op = SE
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindByte, Value: nn}}
+	case 0x4000:
+		ins.Op = SNE
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindByte, Value: nn}}
+	case 0x5000:
+		ins.Op = SE
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindVy, Value: y}}
+	case 0x6000:
+		ins.Op = LD
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindByte, Value: nn}}
+	case 0x7000:
+		ins.Op = ADD
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindByte, Value: nn}}
+	case 0x8000:
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindVy, Value: y}}
+		switch n {
+		case 0x0:
+			ins.Op = LD
+		case 0x1:
+			ins.Op = OR
+		case 0x2:
+			ins.Op = AND
+		case 0x3:
+			ins.Op = XOR
+		case 0x4:
+			ins.Op = ADD
+		case 0x5:
+			ins.Op = SUB
+		case 0x6:
+			ins.Op = SHR
+		case 0x7:
+			ins.Op = SUBN
+		case 0xE:
+			ins.Op = SHL
+		default:
+			ins.Op = DATA
+			ins.Args = nil
+		}
+	case 0x9000:
+		ins.Op = SNE
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindVy, Value: y}}
+	case 0xA000:
+		ins.Op = LD
+		ins.Args = []Arg{{Kind: KindI}, {Kind: KindAddr, Value: nnn}}
+	case 0xB000:
+		ins.Op = JP
+		ins.Args = []Arg{{Kind: KindVx, Value: 0}, {Kind: KindAddr, Value: nnn}}
+	case 0xC000:
+		ins.Op = RND
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindByte, Value: nn}}
+	case 0xD000:
+		ins.Op = DRW
+		ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindVy, Value: y}, {Kind: KindNibble, Value: n}}
+	case 0xE000:
+		switch nn {
+		case 0x9E:
+			ins.Op = SKP
+			ins.Args = []Arg{{Kind: KindVx, Value: x}}
+		case 0xA1:
+			ins.Op = SKNP
+			ins.Args = []Arg{{Kind: KindVx, Value: x}}
+		default:
+			ins.Op = DATA
+		}
+	case 0xF000:
+		ins.Op = LD
+		switch nn {
+		case 0x07:
+			ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindDT}}
+		case 0x0A:
+			ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindK}}
+		case 0x15:
+			ins.Args = []Arg{{Kind: KindDT}, {Kind: KindVx, Value: x}}
+		case 0x18:
+			ins.Args = []Arg{{Kind: KindST}, {Kind: KindVx, Value: x}}
+		case 0x1E:
+			ins.Op = ADD
+			ins.Args = []Arg{{Kind: KindI}, {Kind: KindVx, Value: x}}
+		case 0x29:
+			ins.Args = []Arg{{Kind: KindF}, {Kind: KindVx, Value: x}}
+		case 0x33:
+			ins.Args = []Arg{{Kind: KindB}, {Kind: KindVx, Value: x}}
+		case 0x55:
+			ins.Args = []Arg{{Kind: KindI, Value: indirectI}, {Kind: KindVx, Value: x}}
+		case 0x65:
+			ins.Args = []Arg{{Kind: KindVx, Value: x}, {Kind: KindI, Value: indirectI}}
+		default:
+			ins.Op = DATA
+		}
+	default:
+		ins.Op = DATA
+	}
+
+	return ins
+}
@@ -0,0 +1,116 @@
+// Package asm provides a pure, stateless CHIP-8 disassembler/assembler. It
+// mirrors the opcode switch in chip8.Chip8.Decode but never touches emulator
+// state, so it can be used for debugger UIs and ROM analysis tooling.
+package asm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpCode is a canonical CHIP-8 mnemonic.
+type OpCode string
+
+const (
+	CLS  OpCode = "CLS"
+	RET  OpCode = "RET"
+	JP   OpCode = "JP"
+	CALL OpCode = "CALL"
+	SE   OpCode = "SE"
+	SNE  OpCode = "SNE"
+	LD   OpCode = "LD"
+	ADD  OpCode = "ADD"
+	OR   OpCode = "OR"
+	AND  OpCode = "AND"
+	XOR  OpCode = "XOR"
+	SUB  OpCode = "SUB"
+	SHR  OpCode = "SHR"
+	SUBN OpCode = "SUBN"
+	SHL  OpCode = "SHL"
+	RND  OpCode = "RND"
+	DRW  OpCode = "DRW"
+	SKP  OpCode = "SKP"
+	SKNP OpCode = "SKNP"
+	// DATA marks a raw word that did not decode to a known instruction.
+	DATA OpCode = "DATA"
+)
+
+// ArgKind identifies what an Arg refers to.
+type ArgKind int
+
+const (
+	KindVx ArgKind = iota
+	KindVy
+	KindNibble
+	KindByte
+	KindAddr
+	KindI
+	KindDT
+	KindST
+	KindK
+	KindF
+	KindB
+)
+
+// Arg is one operand of a decoded Instruction. Value is only meaningful for
+// KindVx, KindVy, KindNibble, KindByte and KindAddr; the remaining kinds
+// (KindDT, KindST, KindK, KindF, KindB) name a fixed pseudo-register and
+// carry no value. KindI uses Value as a 0/1 flag: 0 renders as "I" (e.g. LD
+// I, addr), 1 renders as "[I]" (e.g. LD [I], Vx).
+type Arg struct {
+	Kind  ArgKind
+	Value uint16
+}
+
+const indirectI = 1
+
+func (a Arg) String() string {
+	switch a.Kind {
+	case KindVx, KindVy:
+		return fmt.Sprintf("V%X", a.Value)
+	case KindNibble:
+		return fmt.Sprintf("%d", a.Value)
+	case KindByte:
+		return fmt.Sprintf("0x%02X", a.Value)
+	case KindAddr:
+		return fmt.Sprintf("0x%03X", a.Value)
+	case KindI:
+		if a.Value == indirectI {
+			return "[I]"
+		}
+		return "I"
+	case KindDT:
+		return "DT"
+	case KindST:
+		return "ST"
+	case KindK:
+		return "K"
+	case KindF:
+		return "F"
+	case KindB:
+		return "B"
+	default:
+		return "?"
+	}
+}
+
+// Instruction is one decoded CHIP-8 word.
+type Instruction struct {
+	Addr uint16
+	Raw  uint16
+	Op   OpCode
+	Args []Arg
+}
+
+// String renders the canonical CHIP-8 mnemonic for the instruction, e.g.
+// "LD V0, 0x0A" or "DRW V1, V2, 5".
+func (ins Instruction) String() string {
+	if len(ins.Args) == 0 {
+		return string(ins.Op)
+	}
+	parts := make([]string, len(ins.Args))
+	for i, a := range ins.Args {
+		parts[i] = a.String()
+	}
+	return fmt.Sprintf("%s %s", ins.Op, strings.Join(parts, ", "))
+}
@@ -0,0 +1,87 @@
+package chip8
+
+import "testing"
+
+func newTestChip8(t *testing.T, program ...uint16) *Chip8 {
+	t.Helper()
+	c := NewChip8(Options{})
+	for i, op := range program {
+		addr := 0x200 + uint16(i)*2
+		c.memory[addr] = byte(op >> 8)
+		c.memory[addr+1] = byte(op)
+	}
+	return c
+}
+
+func TestEmulateCycleAdvancesPcByTwo(t *testing.T) {
+	c := newTestChip8(t, 0x6001) // LD V0, 0x01
+	c.EmulateCycle()
+	if c.pc != 0x202 {
+		t.Fatalf("pc = 0x%X, want 0x202", c.pc)
+	}
+	if c.V[0] != 1 {
+		t.Fatalf("V0 = %d, want 1", c.V[0])
+	}
+}
+
+func TestEmulateCycleJumpLandsExactlyOnTarget(t *testing.T) {
+	c := newTestChip8(t, 0x1300) // JP 0x300
+	c.EmulateCycle()
+	if c.pc != 0x300 {
+		t.Fatalf("pc = 0x%X, want 0x300", c.pc)
+	}
+}
+
+func TestEmulateCycleCallAndReturn(t *testing.T) {
+	c := newTestChip8(t, 0x2300) // CALL 0x300
+	c.memory[0x300] = 0x00
+	c.memory[0x301] = 0xEE // RET
+
+	c.EmulateCycle() // CALL
+	if c.pc != 0x300 {
+		t.Fatalf("pc after CALL = 0x%X, want 0x300", c.pc)
+	}
+	if c.sp != 1 || c.stack[0] != 0x202 {
+		t.Fatalf("stack = %v, sp = %d; want [0x202] sp=1", c.stack[:1], c.sp)
+	}
+
+	c.EmulateCycle() // RET
+	if c.pc != 0x202 {
+		t.Fatalf("pc after RET = 0x%X, want 0x202", c.pc)
+	}
+}
+
+func TestEmulateCycleSkipEqualAdvancesFour(t *testing.T) {
+	c := newTestChip8(t, 0x3001) // SE V0, 0x01 (V0 starts at 0, so no skip)
+	c.EmulateCycle()
+	if c.pc != 0x202 {
+		t.Fatalf("pc = 0x%X, want 0x202 (no skip)", c.pc)
+	}
+
+	c = newTestChip8(t, 0x3000) // SE V0, 0x00 (V0 starts at 0, matches, skip)
+	c.EmulateCycle()
+	if c.pc != 0x204 {
+		t.Fatalf("pc = 0x%X, want 0x204 (skip)", c.pc)
+	}
+}
+
+func TestEmulateCycleRndMasksWithKk(t *testing.T) {
+	c := newTestChip8(t, 0xC000) // RND V0, 0x00: AND with 0 always yields 0
+	c.EmulateCycle()
+	if c.V[0] != 0 {
+		t.Fatalf("RND V0, 0x00 = 0x%X, want 0x00 (masked to nothing)", c.V[0])
+	}
+}
+
+func TestEmulateCycleAddCarry(t *testing.T) {
+	c := newTestChip8(t, 0x60FF, 0x6101, 0x8014) // LD V0,0xFF; LD V1,0x01; ADD V0,V1
+	c.EmulateCycle()
+	c.EmulateCycle()
+	c.EmulateCycle()
+	if c.V[0] != 0x00 {
+		t.Fatalf("V0 = 0x%X, want 0x00 (wrapped)", c.V[0])
+	}
+	if c.V[0xF] != 1 {
+		t.Fatalf("VF = %d, want 1 (carry)", c.V[0xF])
+	}
+}
@@ -0,0 +1,86 @@
+package chip8
+
+import (
+	"context"
+	"time"
+)
+
+// defaultCyclesPerSecond is used when Options.CyclesPerSecond is unset,
+// comfortably inside the ~500-700Hz most CHIP-8 ROMs were timed against.
+const defaultCyclesPerSecond = 540
+
+const timerHz = 60
+
+// Clock decouples instruction dispatch from timer decrement: the CPU runs
+// at CyclesPerSecond while the delay/sound timers always tick at 60Hz,
+// regardless of how fast instructions are executing.
+type Clock struct {
+	// CyclesPerSecond is how many instructions to execute per second.
+	// Zero or negative defaults to defaultCyclesPerSecond.
+	CyclesPerSecond int
+}
+
+// Run drives c with this clock until ctx is cancelled. When
+// c's Quirks.DrawWaitsForVBlank is set, DRW blocks until the next 60Hz tick
+// fired by this Clock.
+//
+// Timer ticking runs on its own goroutine, separate from the one dispatching
+// EmulateCycle. A DRW that blocks on vblank blocks the CPU goroutine, not
+// the timer goroutine - if both lived on the same call stack, that DRW could
+// never be unblocked, since the only sender for vblank would be stuck behind
+// it.
+//
+// c is not otherwise safe for concurrent use - callers must not run a
+// Debugger or Rewind against the same Chip8 while Run is driving it, same
+// as before this split. TickTimers decrements delayedTimer/soundTimer with
+// sync/atomic, and FX07/FX15/FX18 read and write them the same way, so the CPU
+// and timer goroutines can touch those two fields concurrently without a
+// data race; every other field is still off-limits across goroutines.
+func (cl Clock) Run(ctx context.Context, c *Chip8) error {
+	cps := cl.CyclesPerSecond
+	if cps <= 0 {
+		cps = defaultCyclesPerSecond
+	}
+
+	vblank := make(chan struct{}, 1)
+	c.vblank = vblank
+	defer func() { c.vblank = nil }()
+
+	timerDone := make(chan struct{})
+	go func() {
+		defer close(timerDone)
+		timerTicker := time.NewTicker(time.Second / timerHz)
+		defer timerTicker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timerTicker.C:
+				c.TickTimers()
+				select {
+				case vblank <- struct{}{}:
+				default: // previous vblank wasn't consumed yet; drop it
+				}
+			}
+		}
+	}()
+
+	cpuTicker := time.NewTicker(time.Second / time.Duration(cps))
+	defer cpuTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			<-timerDone
+			return ctx.Err()
+		case <-cpuTicker.C:
+			c.EmulateCycle()
+		}
+	}
+}
+
+// Run drives the emulator at c.cyclesPerSecond (set via
+// Options.CyclesPerSecond) until ctx is cancelled.
+func (c *Chip8) Run(ctx context.Context) error {
+	return Clock{CyclesPerSecond: c.cyclesPerSecond}.Run(ctx, c)
+}
@@ -0,0 +1,54 @@
+package chip8
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestClockRunVBlankDrawDoesNotDeadlock exercises Run with
+// Quirks.DrawWaitsForVBlank set and a ROM that DRWs in a tight loop. Before the
+// timer goroutine split, the only sender on c.vblank shared a call stack
+// with the blocked DRW, so this ROM would hang Run forever and the test
+// would time out.
+func TestClockRunVBlankDrawDoesNotDeadlock(t *testing.T) {
+	c := NewChip8(Options{Quirks: Quirks{DrawWaitsForVBlank: true}, CyclesPerSecond: 1000})
+	c.memory[0x200] = 0xD0 // DRW V0, V0, 1
+	c.memory[0x201] = 0x01
+	c.memory[0x202] = 0x12 // JP 0x200
+	c.memory[0x203] = 0x00
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Run returned %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return within 2s; DRW + DrawWaitsForVBlank deadlocked")
+	}
+}
+
+// TestClockRunTimerAccessIsRaceFree drives a ROM that sets the sound timer
+// in a tight loop while Run's timer goroutine is decrementing it 60 times a
+// second. Under -race, this fails unless delayedTimer/soundTimer are
+// accessed atomically on both goroutines.
+func TestClockRunTimerAccessIsRaceFree(t *testing.T) {
+	c := NewChip8(Options{CyclesPerSecond: 2000})
+	c.memory[0x200] = 0xF0 // LD ST, V0
+	c.memory[0x201] = 0x18
+	c.memory[0x202] = 0x12 // JP 0x200
+	c.memory[0x203] = 0x00
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Run returned %v, want context.DeadlineExceeded", err)
+	}
+}
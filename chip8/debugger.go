@@ -0,0 +1,214 @@
+package chip8
+
+import (
+	"fmt"
+
+	"github.com/tomassirio/Chip8/chip8/asm"
+)
+
+// EventKind identifies what a Debugger Event is reporting.
+type EventKind int
+
+const (
+	BreakpointHit EventKind = iota
+	IllegalOpcode
+	StackOverflow
+)
+
+// Event is emitted on a Debugger's event channel in place of the interpreter
+// panicking or logging directly.
+type Event struct {
+	Kind    EventKind
+	PC      uint16
+	Opcode  Opcode
+	Message string
+}
+
+// WatchKind identifies what access a Watchpoint fires on.
+type WatchKind int
+
+const (
+	WatchWrite WatchKind = iota
+	WatchRead
+)
+
+// Watchpoint fires when memory at Addr is accessed the way Kind describes.
+// Read watchpoints are recorded but, without instrumenting every memory
+// access, are only evaluated on a best-effort basis alongside writes.
+type Watchpoint struct {
+	Addr uint16
+	Kind WatchKind
+}
+
+// RegisterSnapshot is a point-in-time copy of the CPU-visible registers.
+type RegisterSnapshot struct {
+	V          Registers
+	I, PC, SP  uint16
+	DelayTimer byte
+	SoundTimer byte
+}
+
+// Debugger wraps a Chip8 with breakpoints, stepping, memory/register
+// inspection and bounded reverse-step, the substrate a TUI/GUI front-end
+// needs to drive the emulator interactively.
+type Debugger struct {
+	chip *Chip8
+
+	breakpoints map[uint16]bool
+	watchpoints []Watchpoint
+	watchPrev   map[uint16]byte
+
+	events  chan Event
+	paused  bool
+
+	history      []Chip8
+	historyHead  int
+	historyLen   int
+	historyDepth int
+}
+
+// NewDebugger wraps c. historyDepth bounds how many prior states StepBack can
+// rewind through; 0 disables history tracking.
+func NewDebugger(c *Chip8, historyDepth int) *Debugger {
+	events := make(chan Event, 16)
+	c.events = events
+	return &Debugger{
+		chip:         c,
+		breakpoints:  map[uint16]bool{},
+		watchPrev:    map[uint16]byte{},
+		events:       events,
+		history:      make([]Chip8, historyDepth),
+		historyDepth: historyDepth,
+	}
+}
+
+// Events returns the channel Breakpoint/IllegalOpcode/StackOverflow events
+// are delivered on.
+func (d *Debugger) Events() <-chan Event {
+	return d.events
+}
+
+// AddBreakpoint halts Continue whenever pc reaches addr.
+func (d *Debugger) AddBreakpoint(pc uint16) {
+	d.breakpoints[pc] = true
+}
+
+// AddWatchpoint records addr/kind so Step can report changes around it.
+func (d *Debugger) AddWatchpoint(addr uint16, kind WatchKind) {
+	d.watchpoints = append(d.watchpoints, Watchpoint{Addr: addr, Kind: kind})
+	d.watchPrev[addr] = d.chip.memory[addr]
+}
+
+// Step runs exactly one CPU cycle, recording history and checking
+// watchpoints. It reports whether a breakpoint or watchpoint fired.
+func (d *Debugger) Step() bool {
+	d.pushHistory()
+	d.chip.fault = nil
+	d.chip.EmulateCycle()
+	hit := d.checkWatchpoints()
+	if d.breakpoints[d.chip.pc] {
+		d.emit(Event{Kind: BreakpointHit, PC: d.chip.pc})
+		hit = true
+	}
+	if d.chip.fault != nil {
+		// Already queued onto d.events by reportIllegalOpcode/reportStackOverflow;
+		// just make sure Continue halts instead of re-decoding it forever.
+		hit = true
+	}
+	return hit
+}
+
+// Continue steps until a breakpoint/watchpoint is hit or Pause is called.
+func (d *Debugger) Continue() {
+	d.paused = false
+	for !d.paused {
+		if d.Step() {
+			d.paused = true
+		}
+	}
+}
+
+// Pause stops a running Continue loop after its current Step completes.
+func (d *Debugger) Pause() {
+	d.paused = true
+}
+
+// Registers returns a copy of the CPU-visible register file.
+func (d *Debugger) Registers() RegisterSnapshot {
+	return RegisterSnapshot{
+		V:          d.chip.V,
+		I:          d.chip.ir,
+		PC:         d.chip.pc,
+		SP:         d.chip.sp,
+		DelayTimer: byte(d.chip.delayedTimer),
+		SoundTimer: byte(d.chip.soundTimer),
+	}
+}
+
+// Memory returns a copy of memory in [start, end).
+func (d *Debugger) Memory(start, end uint16) []byte {
+	out := make([]byte, end-start)
+	copy(out, d.chip.memory[start:end])
+	return out
+}
+
+// Disassemble decodes count instructions starting at pc.
+func (d *Debugger) Disassemble(pc uint16, count int) []asm.Instruction {
+	end := int(pc) + count*2
+	if end > len(d.chip.memory) {
+		end = len(d.chip.memory)
+	}
+	return asm.Disassemble(d.chip.memory[pc:end], pc)
+}
+
+// StepBack rewinds the emulator by n cycles using the history ring buffer,
+// returning an error if fewer than n prior states are available.
+func (d *Debugger) StepBack(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("chip8: StepBack n must be positive, got %d", n)
+	}
+	if n > d.historyLen {
+		return fmt.Errorf("chip8: only %d prior state(s) available, asked for %d", d.historyLen, n)
+	}
+	idx := (d.historyHead - n + d.historyDepth) % d.historyDepth
+	*d.chip = d.history[idx]
+	d.historyHead = idx
+	d.historyLen -= n
+	return nil
+}
+
+func (d *Debugger) emit(e Event) {
+	select {
+	case d.events <- e:
+	default: // drop if nothing is currently reading Events()
+	}
+}
+
+func (d *Debugger) pushHistory() {
+	if d.historyDepth == 0 {
+		return
+	}
+	d.history[d.historyHead] = *d.chip
+	d.historyHead = (d.historyHead + 1) % d.historyDepth
+	if d.historyLen < d.historyDepth {
+		d.historyLen++
+	}
+}
+
+// checkWatchpoints compares watched bytes against their previous value,
+// emitting a BreakpointHit-style event and reporting true on change. Read
+// watchpoints aren't detected this way (no access is instrumented) and are
+// only ever satisfied if the same address is also written.
+func (d *Debugger) checkWatchpoints() bool {
+	hit := false
+	for _, w := range d.watchpoints {
+		cur := d.chip.memory[w.Addr]
+		if cur == d.watchPrev[w.Addr] {
+			continue
+		}
+		d.watchPrev[w.Addr] = cur
+		d.emit(Event{Kind: BreakpointHit, PC: d.chip.pc, Message: fmt.Sprintf("watchpoint 0x%X changed to 0x%02X", w.Addr, cur)})
+		hit = true
+	}
+	return hit
+}
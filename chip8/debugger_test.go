@@ -0,0 +1,100 @@
+package chip8
+
+import "testing"
+
+func TestDebuggerStepAdvancesOneInstruction(t *testing.T) {
+	c := newTestChip8(t, 0x6001, 0x6102) // LD V0,0x01; LD V1,0x02
+	d := NewDebugger(c, 4)
+
+	d.Step()
+	if c.pc != 0x202 || c.V[0] != 1 {
+		t.Fatalf("after one Step: pc=0x%X V0=%d, want pc=0x202 V0=1", c.pc, c.V[0])
+	}
+
+	d.Step()
+	if c.pc != 0x204 || c.V[1] != 2 {
+		t.Fatalf("after two Steps: pc=0x%X V1=%d, want pc=0x204 V1=2", c.pc, c.V[1])
+	}
+}
+
+func TestDebuggerBreakpointStopsContinue(t *testing.T) {
+	c := newTestChip8(t, 0x6001, 0x6102, 0x6203) // three LDs
+	d := NewDebugger(c, 4)
+	d.AddBreakpoint(0x204) // after the second instruction
+
+	d.Continue()
+
+	if c.pc != 0x204 {
+		t.Fatalf("Continue stopped at pc=0x%X, want 0x204", c.pc)
+	}
+	select {
+	case ev := <-d.Events():
+		if ev.Kind != BreakpointHit || ev.PC != 0x204 {
+			t.Fatalf("got event %+v, want BreakpointHit at 0x204", ev)
+		}
+	default:
+		t.Fatal("no event emitted for breakpoint hit")
+	}
+}
+
+func TestDebuggerStepBackRewindsState(t *testing.T) {
+	c := newTestChip8(t, 0x6001, 0x6102)
+	d := NewDebugger(c, 4)
+
+	d.Step()
+	d.Step()
+	if c.V[0] != 1 || c.V[1] != 2 {
+		t.Fatalf("V0=%d V1=%d after two steps, want 1, 2", c.V[0], c.V[1])
+	}
+
+	if err := d.StepBack(1); err != nil {
+		t.Fatalf("StepBack: %v", err)
+	}
+	if c.pc != 0x202 || c.V[1] != 0 {
+		t.Fatalf("after StepBack(1): pc=0x%X V1=%d, want pc=0x202 V1=0", c.pc, c.V[1])
+	}
+}
+
+func TestDebuggerStepBackErrorsPastHistory(t *testing.T) {
+	c := newTestChip8(t, 0x6001)
+	d := NewDebugger(c, 4)
+	d.Step()
+	if err := d.StepBack(2); err == nil {
+		t.Fatal("StepBack(2) succeeded with only 1 step of history")
+	}
+}
+
+func TestDebuggerContinueStopsOnIllegalOpcode(t *testing.T) {
+	c := newTestChip8(t, 0xFFFF) // not a valid opcode
+	d := NewDebugger(c, 4)
+
+	d.Continue()
+	if c.pc != 0x200 {
+		t.Fatalf("pc = 0x%X after Continue, want unchanged 0x200 (illegal opcode halts, doesn't advance)", c.pc)
+	}
+
+	select {
+	case ev := <-d.Events():
+		if ev.Kind != IllegalOpcode {
+			t.Fatalf("got event %+v, want IllegalOpcode", ev)
+		}
+	default:
+		t.Fatal("no event emitted for illegal opcode")
+	}
+}
+
+func TestDebuggerRegistersAndMemory(t *testing.T) {
+	c := newTestChip8(t, 0x60AB)
+	d := NewDebugger(c, 4)
+	d.Step()
+
+	regs := d.Registers()
+	if regs.V[0] != 0xAB || regs.PC != 0x202 {
+		t.Fatalf("Registers() = %+v, want V0=0xAB PC=0x202", regs)
+	}
+
+	mem := d.Memory(0x200, 0x202)
+	if len(mem) != 2 || mem[0] != 0x60 || mem[1] != 0xAB {
+		t.Fatalf("Memory(0x200,0x202) = %v, want [0x60 0xAB]", mem)
+	}
+}
@@ -0,0 +1,37 @@
+package chip8
+
+// Display receives the framebuffer whenever DRW or CLS changes it. Draw
+// receives a full copy of the 64x32 1-bit-per-byte framebuffer each time.
+type Display interface {
+	Draw(gfx [GFX_SIZE]byte)
+	Clear()
+}
+
+// Keypad reports the state of the 16-key CHIP-8 keypad. WaitKey blocks
+// until a key is pressed and returns it; it backs the FX0A opcode.
+type Keypad interface {
+	Pressed(key byte) bool
+	WaitKey() byte
+}
+
+// Beeper is driven by the sound timer: Start is called when it becomes
+// non-zero, Stop when it reaches zero.
+type Beeper interface {
+	Start()
+	Stop()
+}
+
+type noopDisplay struct{}
+
+func (noopDisplay) Draw([GFX_SIZE]byte) {}
+func (noopDisplay) Clear()              {}
+
+type noopKeypad struct{}
+
+func (noopKeypad) Pressed(byte) bool { return false }
+func (noopKeypad) WaitKey() byte     { return 0 }
+
+type noopBeeper struct{}
+
+func (noopBeeper) Start() {}
+func (noopBeeper) Stop()  {}
@@ -0,0 +1,224 @@
+// Package jit compiles straight-line runs of CHIP-8 instructions - a basic
+// block, from the current PC until the first branch, call, return, DRW or
+// key-wait - into a single Go closure, so the interpreter can execute many
+// instructions per EmulateCycle instead of one. It has no notion of a
+// Chip8; callers hand it a CPU, a thin view over the registers a compiled
+// block needs to touch.
+package jit
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// CPU is the subset of emulator state a compiled Block can read and write.
+// The caller owns the backing memory and wires these pointers to its own
+// fields; jit never allocates emulator state itself.
+type CPU struct {
+	V           *[16]byte
+	I           *uint16
+	PC          *uint16
+	ShiftUsesVy bool
+}
+
+// Block is a compiled basic block: Run executes every inlined instruction
+// from Start up to (not including) End, and leaves *CPU.PC at End. The
+// caller is responsible for interpreting whatever instruction lives at End,
+// since that's what ended the block.
+type Block struct {
+	Start, End uint16
+	Run        func(cpu *CPU)
+}
+
+type key struct {
+	pc   uint16
+	hash uint64
+}
+
+// Cache compiles and memoizes Blocks keyed by (pc, hash of the block's
+// bytes), so a write to memory inside a cached block's range - e.g. an
+// FX55 store, or a self-modifying ROM - naturally misses the cache the next
+// time that pc runs, without Cache having to watch every memory write
+// itself. Invalidate additionally evicts entries eagerly, so a block that's
+// rewritten but never re-executed doesn't linger forever.
+type Cache struct {
+	mu     sync.Mutex
+	blocks map[key]*Block
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{blocks: map[key]*Block{}}
+}
+
+// Get returns the compiled Block starting at pc, compiling and caching it
+// if this is the first time this exact (pc, bytes) pair has been seen. It
+// returns nil if the instruction at pc isn't one compile can inline, e.g. a
+// jump or an opcode that touches I/O or timers.
+func (c *Cache) Get(memory []byte, pc uint16, shiftUsesVy bool) *Block {
+	end := scanBlockEnd(memory, pc)
+	if end == pc {
+		return nil
+	}
+
+	h := hashRange(memory[pc:end])
+	k := key{pc: pc, hash: h}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.blocks[k]; ok {
+		return b
+	}
+	b := compile(memory, pc, end, shiftUsesVy)
+	c.blocks[k] = b
+	return b
+}
+
+// Invalidate evicts every cached block that overlaps [start, end), e.g.
+// after a store through I rewrites that range.
+func (c *Cache) Invalidate(start, end uint16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, b := range c.blocks {
+		if b.Start < end && start < b.End {
+			delete(c.blocks, k)
+		}
+	}
+}
+
+func hashRange(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+// scanBlockEnd returns the address one past the last inlineable
+// instruction starting at pc, without compiling anything.
+func scanBlockEnd(memory []byte, pc uint16) uint16 {
+	p := pc
+	for int(p)+1 < len(memory) {
+		raw := uint16(memory[p])<<8 | uint16(memory[p+1])
+		if !inlineable(raw) {
+			break
+		}
+		p += 2
+	}
+	return p
+}
+
+// inlineable reports whether raw is a pure register/ALU instruction with no
+// branching, I/O or timer side effects - the only kind a Block inlines.
+// Everything else (jumps, calls, returns, skips, DRW, key/timer opcodes)
+// ends the block and falls back to the interpreter.
+func inlineable(raw uint16) bool {
+	switch raw & 0xF000 {
+	case 0x6000, 0x7000, 0xA000, 0xC000:
+		return true
+	case 0x8000:
+		switch raw & 0x000F {
+		case 0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0xE:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func compile(memory []byte, start, end uint16, shiftUsesVy bool) *Block {
+	var ops []func(*CPU)
+	for p := start; p < end; p += 2 {
+		raw := uint16(memory[p])<<8 | uint16(memory[p+1])
+		ops = append(ops, compileOp(raw))
+	}
+	return &Block{
+		Start: start,
+		End:   end,
+		Run: func(cpu *CPU) {
+			for _, op := range ops {
+				op(cpu)
+			}
+			*cpu.PC = end
+		},
+	}
+}
+
+func compileOp(raw uint16) func(*CPU) {
+	x := (raw & 0x0F00) >> 8
+	y := (raw & 0x00F0) >> 4
+	nn := byte(raw & 0x00FF)
+	nnn := raw & 0x0FFF
+
+	switch raw & 0xF000 {
+	case 0x6000: // LD Vx, byte
+		return func(cpu *CPU) { cpu.V[x] = nn }
+	case 0x7000: // ADD Vx, byte
+		return func(cpu *CPU) { cpu.V[x] += nn }
+	case 0xA000: // LD I, addr
+		return func(cpu *CPU) { *cpu.I = nnn }
+	case 0xC000: // RND Vx, byte
+		return func(cpu *CPU) { cpu.V[x] = byte(rand.Intn(256)) & nn }
+	case 0x8000:
+		switch raw & 0x000F {
+		case 0x0: // LD Vx, Vy
+			return func(cpu *CPU) { cpu.V[x] = cpu.V[y] }
+		case 0x1: // OR Vx, Vy
+			return func(cpu *CPU) { cpu.V[x] |= cpu.V[y] }
+		case 0x2: // AND Vx, Vy
+			return func(cpu *CPU) { cpu.V[x] &= cpu.V[y] }
+		case 0x3: // XOR Vx, Vy
+			return func(cpu *CPU) { cpu.V[x] ^= cpu.V[y] }
+		case 0x4: // ADD Vx, Vy, set VF = carry
+			return func(cpu *CPU) {
+				sum := uint16(cpu.V[x]) + uint16(cpu.V[y])
+				cpu.V[x] = byte(sum)
+				if sum > 0xFF {
+					cpu.V[0xF] = 1
+				} else {
+					cpu.V[0xF] = 0
+				}
+			}
+		case 0x5: // SUB Vx, Vy, set VF = NOT borrow
+			return func(cpu *CPU) {
+				borrow := cpu.V[x] < cpu.V[y]
+				cpu.V[x] -= cpu.V[y]
+				if borrow {
+					cpu.V[0xF] = 0
+				} else {
+					cpu.V[0xF] = 1
+				}
+			}
+		case 0x6: // SHR Vx {, Vy}
+			return func(cpu *CPU) {
+				v := cpu.V[x]
+				if cpu.ShiftUsesVy {
+					v = cpu.V[y]
+				}
+				cpu.V[x] = v >> 1
+				cpu.V[0xF] = v & 0x1
+			}
+		case 0x7: // SUBN Vx, Vy, set VF = NOT borrow
+			return func(cpu *CPU) {
+				borrow := cpu.V[y] < cpu.V[x]
+				cpu.V[x] = cpu.V[y] - cpu.V[x]
+				if borrow {
+					cpu.V[0xF] = 0
+				} else {
+					cpu.V[0xF] = 1
+				}
+			}
+		case 0xE: // SHL Vx {, Vy}
+			return func(cpu *CPU) {
+				v := cpu.V[x]
+				if cpu.ShiftUsesVy {
+					v = cpu.V[y]
+				}
+				cpu.V[x] = v << 1
+				cpu.V[0xF] = v >> 7
+			}
+		}
+	}
+	panic("jit: compileOp called on non-inlineable opcode")
+}
@@ -0,0 +1,93 @@
+package chip8
+
+import "testing"
+
+func newTestChip8WithQuirks(t *testing.T, q Quirks, program ...uint16) *Chip8 {
+	t.Helper()
+	c := NewChip8(Options{Quirks: q})
+	for i, op := range program {
+		addr := 0x200 + uint16(i)*2
+		c.memory[addr] = byte(op >> 8)
+		c.memory[addr+1] = byte(op)
+	}
+	return c
+}
+
+func TestShiftUsesVyQuirk(t *testing.T) {
+	// 0x8016: SHR V0 {, V1}
+	withVy := newTestChip8WithQuirks(t, Quirks{ShiftUsesVy: true}, 0x8016)
+	withVy.V[0] = 0xFF
+	withVy.V[1] = 0x04 // ...0100, LSB 0
+	withVy.EmulateCycle()
+	if withVy.V[0] != 0x02 || withVy.V[0xF] != 0 {
+		t.Fatalf("ShiftUsesVy=true: V0=0x%X VF=%d, want V0=0x02 VF=0 (shifted V1)", withVy.V[0], withVy.V[0xF])
+	}
+
+	withoutVy := newTestChip8WithQuirks(t, Quirks{ShiftUsesVy: false}, 0x8016)
+	withoutVy.V[0] = 0x05 // ...0101, LSB 1
+	withoutVy.V[1] = 0x04
+	withoutVy.EmulateCycle()
+	if withoutVy.V[0] != 0x02 || withoutVy.V[0xF] != 1 {
+		t.Fatalf("ShiftUsesVy=false: V0=0x%X VF=%d, want V0=0x02 VF=1 (shifted V0)", withoutVy.V[0], withoutVy.V[0xF])
+	}
+}
+
+func TestLoadStoreIncrementsIQuirk(t *testing.T) {
+	// 0xF155: LD [I], V1 (store V0..V1)
+	incrementing := newTestChip8WithQuirks(t, Quirks{LoadStoreIncrementsI: true}, 0xF155)
+	incrementing.ir = 0x300
+	incrementing.EmulateCycle()
+	if incrementing.ir != 0x302 {
+		t.Fatalf("LoadStoreIncrementsI=true: ir = 0x%X, want 0x302", incrementing.ir)
+	}
+
+	flat := newTestChip8WithQuirks(t, Quirks{LoadStoreIncrementsI: false}, 0xF155)
+	flat.ir = 0x300
+	flat.EmulateCycle()
+	if flat.ir != 0x300 {
+		t.Fatalf("LoadStoreIncrementsI=false: ir = 0x%X, want unchanged 0x300", flat.ir)
+	}
+}
+
+func TestJumpWithVxQuirk(t *testing.T) {
+	// 0xB300: JP V0, 0x300 (or JP XNN+Vx under the quirk, X=3)
+	withVx := newTestChip8WithQuirks(t, Quirks{JumpWithVx: true}, 0xB300)
+	withVx.V[3] = 0x05
+	withVx.EmulateCycle()
+	if withVx.pc != 0x305 {
+		t.Fatalf("JumpWithVx=true: pc = 0x%X, want 0x305 (0x300 + V3)", withVx.pc)
+	}
+
+	withV0 := newTestChip8WithQuirks(t, Quirks{JumpWithVx: false}, 0xB300)
+	withV0.V[0] = 0x05
+	withV0.V[3] = 0xFF // must be ignored
+	withV0.EmulateCycle()
+	if withV0.pc != 0x305 {
+		t.Fatalf("JumpWithVx=false: pc = 0x%X, want 0x305 (0x300 + V0)", withV0.pc)
+	}
+}
+
+func TestAddVfBugFix(t *testing.T) {
+	// 0x8014: ADD V0, V1. 0x01 + 0xFF must carry and wrap, not leave VF
+	// stale from some other register's state.
+	c := newTestChip8WithQuirks(t, Quirks{}, 0x8014)
+	c.V[0] = 0x01
+	c.V[1] = 0xFF
+	c.V[0xF] = 0x7 // poison VF so a no-op bug would be caught
+	c.EmulateCycle()
+	if c.V[0] != 0x00 || c.V[0xF] != 1 {
+		t.Fatalf("ADD V0,V1 = 0x%X VF=%d, want 0x00 VF=1", c.V[0], c.V[0xF])
+	}
+}
+
+func TestSubVfBugFix(t *testing.T) {
+	// 0x8015: SUB V0, V1. 0x01 - 0xFF borrows, so VF must clear to 0.
+	c := newTestChip8WithQuirks(t, Quirks{}, 0x8015)
+	c.V[0] = 0x01
+	c.V[1] = 0xFF
+	c.V[0xF] = 0x7 // poison VF
+	c.EmulateCycle()
+	if c.V[0] != 0x02 || c.V[0xF] != 0 {
+		t.Fatalf("SUB V0,V1 = 0x%X VF=%d, want 0x02 VF=0 (borrow)", c.V[0], c.V[0xF])
+	}
+}
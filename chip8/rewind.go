@@ -0,0 +1,66 @@
+package chip8
+
+import "fmt"
+
+// Rewind snapshots a Chip8's SaveState output into a bounded ring buffer and
+// lets callers jump back to an earlier snapshot. Unlike Debugger's in-memory
+// history, Rewind goes through the stable SaveState/LoadState format, so
+// snapshots are cheap to persist or ship elsewhere if a caller wants to.
+type Rewind struct {
+	chip  *Chip8
+	every int // snapshot cadence, in cycles
+
+	cycle int
+	ring  [][]byte
+	head  int
+	len   int
+	depth int
+}
+
+// NewRewind wraps c with a ring buffer of depth snapshots, one taken on
+// every `every`-th call to Tick.
+func NewRewind(c *Chip8, depth, every int) *Rewind {
+	if every < 1 {
+		every = 1
+	}
+	return &Rewind{
+		chip:  c,
+		every: every,
+		ring:  make([][]byte, depth),
+		depth: depth,
+	}
+}
+
+// Tick should be called once per EmulateCycle. It snapshots the wrapped
+// Chip8 every `every` cycles.
+func (r *Rewind) Tick() error {
+	r.cycle++
+	if r.depth == 0 || r.cycle%r.every != 0 {
+		return nil
+	}
+	snapshot, err := r.chip.SaveState()
+	if err != nil {
+		return fmt.Errorf("chip8: Rewind.Tick: %w", err)
+	}
+	r.ring[r.head] = snapshot
+	r.head = (r.head + 1) % r.depth
+	if r.len < r.depth {
+		r.len++
+	}
+	return nil
+}
+
+// StepBack restores the n-th most recent snapshot (n == 1 is the latest)
+// and drops it and every newer snapshot from the ring.
+func (r *Rewind) StepBack(n int) error {
+	if n <= 0 || n > r.len {
+		return fmt.Errorf("chip8: Rewind.StepBack: only %d snapshot(s) available, asked for %d", r.len, n)
+	}
+	idx := (r.head - n + r.depth) % r.depth
+	if err := r.chip.LoadState(r.ring[idx]); err != nil {
+		return fmt.Errorf("chip8: Rewind.StepBack: %w", err)
+	}
+	r.head = idx
+	r.len -= n
+	return nil
+}
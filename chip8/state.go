@@ -0,0 +1,154 @@
+package chip8
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const stateMagic = "CH8S"
+const stateVersion uint16 = 3
+
+// SaveState serializes the full emulator state - registers, memory, display,
+// timers, stack, quirks - into a stable little-endian binary format prefixed
+// by a magic/version header. The format is safe to persist across runs and
+// is also what Rewind snapshots use internally. The attached Display,
+// Keypad and Beeper are not part of the saved state.
+func (c *Chip8) SaveState() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := buf.WriteString(stateMagic); err != nil {
+		return nil, fmt.Errorf("chip8: SaveState: %w", err)
+	}
+
+	fields := []interface{}{
+		stateVersion,
+		quirksToByte(c.quirks),
+		c.V, c.ir, c.pc, c.sp, c.opcode, c.memory, c.gfx,
+		c.delayedTimer, c.soundTimer, c.stack,
+	}
+	for _, f := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, f); err != nil {
+			return nil, fmt.Errorf("chip8: SaveState: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadState restores a state produced by SaveState, replacing every emulator
+// field in one step. The debugger event sink, if any, is preserved across
+// the load since it isn't part of the emulator's logical state.
+func (c *Chip8) LoadState(b []byte) error {
+	r := bytes.NewReader(b)
+
+	magic := make([]byte, len(stateMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return fmt.Errorf("chip8: LoadState: %w", err)
+	}
+	if string(magic) != stateMagic {
+		return fmt.Errorf("chip8: LoadState: bad magic %q", magic)
+	}
+
+	var version uint16
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return fmt.Errorf("chip8: LoadState: %w", err)
+	}
+	if version != stateVersion {
+		return fmt.Errorf("chip8: LoadState: unsupported version %d", version)
+	}
+
+	var quirksByte byte
+	var state Chip8
+	fields := []interface{}{
+		&quirksByte,
+		&state.V, &state.ir, &state.pc, &state.sp, &state.opcode, &state.memory, &state.gfx,
+		&state.delayedTimer, &state.soundTimer, &state.stack,
+	}
+	for _, f := range fields {
+		if err := binary.Read(r, binary.LittleEndian, f); err != nil {
+			return fmt.Errorf("chip8: LoadState: %w", err)
+		}
+	}
+
+	state.quirks = quirksFromByte(quirksByte)
+	state.events = c.events
+	state.display = c.display
+	state.keypad = c.keypad
+	state.beeper = c.beeper
+	state.cyclesPerSecond = c.cyclesPerSecond
+	state.vblank = c.vblank
+	state.jitEnabled = c.jitEnabled
+	state.jitCache = c.jitCache
+	*c = state
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, so a Chip8 can be
+// embedded directly in encoding/gob streams.
+func (c *Chip8) MarshalBinary() ([]byte, error) {
+	return c.SaveState()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *Chip8) UnmarshalBinary(b []byte) error {
+	return c.LoadState(b)
+}
+
+// chip8JSON is the on-wire JSON shape for a Chip8: the same stable bytes
+// SaveState produces, which encoding/json base64-encodes as a []byte field.
+// Every Chip8 field is unexported, so without this, json.Marshal would
+// silently produce "{}" and drop the entire emulator state.
+type chip8JSON struct {
+	State []byte `json:"state"`
+}
+
+// MarshalJSON implements json.Marshaler by wrapping SaveState's output, so a
+// Chip8 round-trips through encoding/json exactly as it does through
+// SaveState/LoadState.
+func (c *Chip8) MarshalJSON() ([]byte, error) {
+	state, err := c.SaveState()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(chip8JSON{State: state})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Chip8) UnmarshalJSON(b []byte) error {
+	var wire chip8JSON
+	if err := json.Unmarshal(b, &wire); err != nil {
+		return fmt.Errorf("chip8: UnmarshalJSON: %w", err)
+	}
+	return c.LoadState(wire.State)
+}
+
+func quirksToByte(q Quirks) byte {
+	var b byte
+	if q.ShiftUsesVy {
+		b |= 1 << 0
+	}
+	if q.LoadStoreIncrementsI {
+		b |= 1 << 1
+	}
+	if q.JumpWithVx {
+		b |= 1 << 2
+	}
+	if q.DrawWraps {
+		b |= 1 << 3
+	}
+	if q.DrawWaitsForVBlank {
+		b |= 1 << 4
+	}
+	return b
+}
+
+func quirksFromByte(b byte) Quirks {
+	return Quirks{
+		ShiftUsesVy:          b&(1<<0) != 0,
+		LoadStoreIncrementsI: b&(1<<1) != 0,
+		JumpWithVx:           b&(1<<2) != 0,
+		DrawWraps:            b&(1<<3) != 0,
+		DrawWaitsForVBlank:   b&(1<<4) != 0,
+	}
+}
@@ -0,0 +1,130 @@
+package chip8
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func chip8WithProgress(t *testing.T) *Chip8 {
+	t.Helper()
+	c := NewChip8(Options{Quirks: Quirks{ShiftUsesVy: true, DrawWraps: true}})
+	c.memory[0x200] = 0x60
+	c.memory[0x201] = 0x2A // LD V0, 0x2A
+	c.EmulateCycle()
+	return c
+}
+
+func assertSameLogicalState(t *testing.T, got, want *Chip8) {
+	t.Helper()
+	if got.V != want.V || got.ir != want.ir || got.pc != want.pc || got.sp != want.sp {
+		t.Fatalf("registers = %+v, want %+v", got.V, want.V)
+	}
+	if got.memory != want.memory {
+		t.Fatalf("memory differs after round-trip")
+	}
+	if got.quirks != want.quirks {
+		t.Fatalf("quirks = %+v, want %+v", got.quirks, want.quirks)
+	}
+}
+
+func TestSaveLoadStateRoundTrip(t *testing.T) {
+	c := chip8WithProgress(t)
+	b, err := c.SaveState()
+	if err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	restored := NewChip8(Options{})
+	if err := restored.LoadState(b); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	assertSameLogicalState(t, restored, c)
+}
+
+func TestLoadStatePreservesRuntimeWiring(t *testing.T) {
+	c := chip8WithProgress(t)
+	b, err := c.SaveState()
+	if err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	restored := NewChip8(Options{})
+	events := make(chan Event, 1)
+	restored.events = events
+	if err := restored.LoadState(b); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if restored.events != (chan<- Event)(events) {
+		t.Fatal("LoadState dropped the events sink instead of preserving it")
+	}
+}
+
+func TestLoadStateRejectsBadMagic(t *testing.T) {
+	c := NewChip8(Options{})
+	if err := c.LoadState([]byte("not a state")); err == nil {
+		t.Fatal("LoadState accepted garbage input")
+	}
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	c := chip8WithProgress(t)
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	restored := NewChip8(Options{})
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	assertSameLogicalState(t, restored, c)
+}
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	c := chip8WithProgress(t)
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	restored := NewChip8(Options{})
+	if err := json.Unmarshal(b, restored); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	assertSameLogicalState(t, restored, c)
+}
+
+func TestRewindStepBack(t *testing.T) {
+	c := NewChip8(Options{})
+	c.memory[0x200] = 0x60
+	c.memory[0x201] = 0x01 // LD V0, 0x01
+	c.memory[0x202] = 0x60
+	c.memory[0x203] = 0x02 // LD V0, 0x02 (never run; V0 is set directly below)
+	r := NewRewind(c, 4, 1)
+
+	c.EmulateCycle() // V0 = 1
+	if err := r.Tick(); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	c.V[0] = 0x63 // simulate further, unrelated progress
+	if err := r.Tick(); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+
+	// StepBack(2) rewinds past both Tick snapshots, back to the state as of
+	// the first one (V0 == 1, before the unrelated progress).
+	if err := r.StepBack(2); err != nil {
+		t.Fatalf("StepBack: %v", err)
+	}
+	if c.V[0] != 0x01 {
+		t.Fatalf("V0 = 0x%X after StepBack(2), want 0x01", c.V[0])
+	}
+}
+
+func TestRewindStepBackErrorsWhenNotEnoughHistory(t *testing.T) {
+	c := NewChip8(Options{})
+	r := NewRewind(c, 4, 1)
+	if err := r.StepBack(1); err == nil {
+		t.Fatal("StepBack succeeded with no snapshots taken")
+	}
+}
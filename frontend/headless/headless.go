@@ -0,0 +1,71 @@
+// Package headless implements chip8.Display, chip8.Keypad and chip8.Beeper
+// entirely in memory, with no window or audio device, for use in tests and
+// other non-interactive harnesses.
+package headless
+
+import "github.com/tomassirio/Chip8/chip8"
+
+var (
+	_ chip8.Display = (*Display)(nil)
+	_ chip8.Keypad  = (*Keypad)(nil)
+	_ chip8.Beeper  = (*Beeper)(nil)
+)
+
+// Display records the last framebuffer drawn and how many times Draw/Clear
+// were called, so tests can assert on emulator output.
+type Display struct {
+	Frame  [64 * 32]byte
+	Draws  int
+	Clears int
+}
+
+func (d *Display) Draw(gfx [64 * 32]byte) {
+	d.Frame = gfx
+	d.Draws++
+}
+
+func (d *Display) Clear() {
+	d.Frame = [64 * 32]byte{}
+	d.Clears++
+}
+
+// Keypad is a Keypad a test drives directly: Press/Release set the state
+// Pressed reports, and SetNextKey queues the value the next WaitKey call
+// returns.
+type Keypad struct {
+	pressed [16]bool
+	nextKey byte
+}
+
+func (k *Keypad) Press(key byte)   { k.pressed[key] = true }
+func (k *Keypad) Release(key byte) { k.pressed[key] = false }
+
+func (k *Keypad) Pressed(key byte) bool {
+	return k.pressed[key]
+}
+
+// SetNextKey sets the value the next WaitKey call returns.
+func (k *Keypad) SetNextKey(key byte) {
+	k.nextKey = key
+}
+
+func (k *Keypad) WaitKey() byte {
+	return k.nextKey
+}
+
+// Beeper records Start/Stop calls instead of making any sound.
+type Beeper struct {
+	Beeping bool
+	Starts  int
+	Stops   int
+}
+
+func (b *Beeper) Start() {
+	b.Beeping = true
+	b.Starts++
+}
+
+func (b *Beeper) Stop() {
+	b.Beeping = false
+	b.Stops++
+}
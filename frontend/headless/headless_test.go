@@ -0,0 +1,53 @@
+package headless
+
+import (
+	"testing"
+
+	"github.com/tomassirio/Chip8/chip8"
+)
+
+func TestDisplayRecordsDrawAndClear(t *testing.T) {
+	d := &Display{}
+	chip8.NewChip8(chip8.Options{Display: d})
+	if d.Clears != 1 {
+		t.Fatalf("Clears = %d after NewChip8, want 1 (reset calls Display.Clear)", d.Clears)
+	}
+
+	d.Draw([64 * 32]byte{0: 1})
+	if d.Draws != 1 || d.Frame[0] != 1 {
+		t.Fatalf("Draws = %d Frame[0] = %d, want 1 and 1", d.Draws, d.Frame[0])
+	}
+}
+
+func TestKeypadPressReleaseAndWaitKey(t *testing.T) {
+	k := &Keypad{}
+	if k.Pressed(5) {
+		t.Fatal("key 5 reported pressed before Press was called")
+	}
+
+	k.Press(5)
+	if !k.Pressed(5) {
+		t.Fatal("key 5 not reported pressed after Press")
+	}
+	k.Release(5)
+	if k.Pressed(5) {
+		t.Fatal("key 5 still reported pressed after Release")
+	}
+
+	k.SetNextKey(0xA)
+	if got := k.WaitKey(); got != 0xA {
+		t.Fatalf("WaitKey() = 0x%X, want 0xA", got)
+	}
+}
+
+func TestBeeperStartStop(t *testing.T) {
+	b := &Beeper{}
+	b.Start()
+	if !b.Beeping || b.Starts != 1 {
+		t.Fatalf("after Start: Beeping=%v Starts=%d, want true 1", b.Beeping, b.Starts)
+	}
+	b.Stop()
+	if b.Beeping || b.Stops != 1 {
+		t.Fatalf("after Stop: Beeping=%v Stops=%d, want false 1", b.Beeping, b.Stops)
+	}
+}
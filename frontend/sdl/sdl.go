@@ -0,0 +1,205 @@
+// Package sdl binds chip8.Display, chip8.Keypad and chip8.Beeper to
+// go-sdl2, giving the emulator a real window, keyboard and audio device.
+//
+// Requires the SDL2 development libraries on the host and
+// github.com/veandco/go-sdl2 as a dependency; it is not exercised by this
+// module's own test suite (see frontend/headless for that).
+package sdl
+
+import (
+	"fmt"
+
+	"github.com/tomassirio/Chip8/chip8"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// keymap lays the 4x4 CHIP-8 keypad over the left side of a QWERTY
+// keyboard: 1234/QWER/ASDF/ZXCV -> 0x0-0xF.
+var keymap = map[sdl.Keycode]byte{
+	sdl.K_1: 0x1, sdl.K_2: 0x2, sdl.K_3: 0x3, sdl.K_4: 0xC,
+	sdl.K_q: 0x4, sdl.K_w: 0x5, sdl.K_e: 0x6, sdl.K_r: 0xD,
+	sdl.K_a: 0x7, sdl.K_s: 0x8, sdl.K_d: 0x9, sdl.K_f: 0xE,
+	sdl.K_z: 0xA, sdl.K_x: 0x0, sdl.K_c: 0xB, sdl.K_v: 0xF,
+}
+
+var (
+	_ chip8.Display = (*Display)(nil)
+	_ chip8.Keypad  = (*Keypad)(nil)
+	_ chip8.Beeper  = (*Beeper)(nil)
+)
+
+// Frontend owns the SDL window, renderer and audio device backing Display,
+// Keypad and Beeper. Call PumpEvents once per host frame to keep Keypad's
+// state and the window responsive.
+type Frontend struct {
+	window   *sdl.Window
+	renderer *sdl.Renderer
+	audio    sdl.AudioDeviceID
+
+	Display *Display
+	Keypad  *Keypad
+	Beeper  *Beeper
+
+	quit bool
+}
+
+// New opens a scale*64 x scale*32 window titled title and a square-wave
+// audio device for the beeper.
+func New(title string, scale int32) (*Frontend, error) {
+	if err := sdl.Init(sdl.INIT_VIDEO | sdl.INIT_AUDIO); err != nil {
+		return nil, fmt.Errorf("sdl: init: %w", err)
+	}
+
+	window, err := sdl.CreateWindow(title, sdl.WINDOWPOS_CENTERED, sdl.WINDOWPOS_CENTERED,
+		64*scale, 32*scale, sdl.WINDOW_SHOWN)
+	if err != nil {
+		return nil, fmt.Errorf("sdl: create window: %w", err)
+	}
+
+	renderer, err := sdl.CreateRenderer(window, -1, sdl.RENDERER_ACCELERATED)
+	if err != nil {
+		window.Destroy()
+		return nil, fmt.Errorf("sdl: create renderer: %w", err)
+	}
+	renderer.SetScale(float32(scale), float32(scale))
+
+	f := &Frontend{window: window, renderer: renderer}
+	f.Display = &Display{renderer: renderer}
+	f.Keypad = &Keypad{}
+	f.Beeper, err = newBeeper()
+	if err != nil {
+		renderer.Destroy()
+		window.Destroy()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Quit reports whether the window close button or Escape was pressed.
+func (f *Frontend) Quit() bool {
+	return f.quit
+}
+
+// PumpEvents drains pending SDL events, updating Keypad and Quit.
+func (f *Frontend) PumpEvents() {
+	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+		switch e := event.(type) {
+		case *sdl.QuitEvent:
+			f.quit = true
+		case *sdl.KeyboardEvent:
+			key, ok := keymap[e.Keysym.Sym]
+			if !ok {
+				if e.Keysym.Sym == sdl.K_ESCAPE {
+					f.quit = true
+				}
+				continue
+			}
+			f.Keypad.set(key, e.State == sdl.PRESSED)
+		}
+	}
+}
+
+// Close releases the window, renderer and audio device.
+func (f *Frontend) Close() {
+	f.Beeper.close()
+	f.renderer.Destroy()
+	f.window.Destroy()
+	sdl.Quit()
+}
+
+// Display renders the CHIP-8 framebuffer as black/white rectangles.
+type Display struct {
+	renderer *sdl.Renderer
+}
+
+func (d *Display) Draw(gfx [64 * 32]byte) {
+	d.renderer.SetDrawColor(0, 0, 0, 255)
+	d.renderer.Clear()
+	d.renderer.SetDrawColor(255, 255, 255, 255)
+	for y := int32(0); y < 32; y++ {
+		for x := int32(0); x < 64; x++ {
+			if gfx[y*64+x] != 0 {
+				d.renderer.FillRect(&sdl.Rect{X: x, Y: y, W: 1, H: 1})
+			}
+		}
+	}
+	d.renderer.Present()
+}
+
+func (d *Display) Clear() {
+	d.renderer.SetDrawColor(0, 0, 0, 255)
+	d.renderer.Clear()
+	d.renderer.Present()
+}
+
+// Keypad tracks which of the 16 CHIP-8 keys PumpEvents last saw held down.
+type Keypad struct {
+	pressed [16]bool
+}
+
+func (k *Keypad) set(key byte, down bool) {
+	k.pressed[key] = down
+}
+
+func (k *Keypad) Pressed(key byte) bool {
+	return k.pressed[key]
+}
+
+// WaitKey blocks, pumping SDL events, until one of the 16 mapped keys goes
+// down.
+func (k *Keypad) WaitKey() byte {
+	for {
+		event := sdl.WaitEvent()
+		e, ok := event.(*sdl.KeyboardEvent)
+		if !ok || e.State != sdl.PRESSED {
+			continue
+		}
+		if key, ok := keymap[e.Keysym.Sym]; ok {
+			k.pressed[key] = true
+			return key
+		}
+	}
+}
+
+// Beeper plays a square wave through SDL's audio queue while running.
+type Beeper struct {
+	device sdl.AudioDeviceID
+	wave   []byte
+}
+
+func newBeeper() (*Beeper, error) {
+	spec := &sdl.AudioSpec{Freq: 44100, Format: sdl.AUDIO_S8, Channels: 1, Samples: 2048}
+	device, err := sdl.OpenAudioDevice("", false, spec, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("sdl: open audio device: %w", err)
+	}
+
+	// Five seconds of tone outlasts the longest beep the 8-bit sound timer
+	// can request (255 ticks at 60Hz).
+	const toneHz = 440
+	period := int(spec.Freq) / toneHz
+	wave := make([]byte, int(spec.Freq)*5)
+	for i := range wave {
+		if i%period < period/2 {
+			wave[i] = 32
+		} else {
+			wave[i] = 0xE0 // -32 as an int8
+		}
+	}
+
+	return &Beeper{device: device, wave: wave}, nil
+}
+
+func (b *Beeper) Start() {
+	sdl.QueueAudio(b.device, b.wave)
+	sdl.PauseAudioDevice(b.device, false)
+}
+
+func (b *Beeper) Stop() {
+	sdl.PauseAudioDevice(b.device, true)
+	sdl.ClearQueuedAudio(b.device)
+}
+
+func (b *Beeper) close() {
+	sdl.CloseAudioDevice(b.device)
+}